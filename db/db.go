@@ -0,0 +1,135 @@
+// Package db implements a small persistent checksum database used to turn
+// the tool into an incremental bit-rot / integrity scanner: for every
+// scanned file it records the size, modification time, and SHA-256 digest
+// last seen, plus when that digest was last verified.
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Record is the stored state for a single file.
+type Record struct {
+	Path       string    `json:"path"`
+	Size       int64     `json:"size"`
+	ModTime    time.Time `json:"mtime"`
+	SHA256     string    `json:"sha256"`
+	VerifiedAt time.Time `json:"verified_at"`
+}
+
+// Unchanged reports whether size and mtime still match this record, i.e.
+// whether the file can be assumed unchanged without recomputing its hash.
+func (r Record) Unchanged(size int64, modTime time.Time) bool {
+	return r.Size == size && r.ModTime.Equal(modTime)
+}
+
+// DB is an in-memory, path-keyed checksum database that can be loaded from
+// and atomically saved back to a JSON file.
+type DB struct {
+	path string
+
+	mu      sync.RWMutex
+	records map[string]Record
+}
+
+// New creates an empty database that will be written to path on Save.
+func New(path string) *DB {
+	return &DB{path: path, records: make(map[string]Record)}
+}
+
+// Load reads the database from path. A missing file is not an error: it
+// yields an empty database that Save will create on first write.
+func Load(path string) (*DB, error) {
+	d := New(path)
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return d, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read database: %w", err)
+	}
+
+	var records []Record
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse database %s: %w", path, err)
+	}
+	for _, r := range records {
+		d.records[r.Path] = r
+	}
+
+	return d, nil
+}
+
+// Get returns the stored record for path, if any.
+func (d *DB) Get(path string) (Record, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	r, ok := d.records[path]
+	return r, ok
+}
+
+// Put inserts or replaces the record for r.Path.
+func (d *DB) Put(r Record) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.records[r.Path] = r
+}
+
+// List returns every record, sorted by path.
+func (d *DB) List() []Record {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	records := make([]Record, 0, len(d.records))
+	for _, r := range d.records {
+		records = append(records, r)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].Path < records[j].Path })
+	return records
+}
+
+// Save writes the database to disk atomically: it writes to a temporary
+// file in the same directory and renames it over the destination, so a
+// process killed mid-write can never leave a truncated or corrupt database.
+func (d *DB) Save() error {
+	records := d.List()
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal database: %w", err)
+	}
+
+	dir := filepath.Dir(d.path)
+	if dir == "" {
+		dir = "."
+	}
+
+	tmp, err := os.CreateTemp(dir, ".db-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary database file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }() // no-op once the rename below succeeds
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write temporary database file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary database file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, d.path); err != nil {
+		return fmt.Errorf("failed to install database file: %w", err)
+	}
+
+	return nil
+}
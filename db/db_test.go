@@ -0,0 +1,86 @@
+package db
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "checksums.json")
+
+	d := New(dbPath)
+	want := Record{
+		Path:       "/data/file.bin",
+		Size:       1024,
+		ModTime:    time.Now().Truncate(time.Second),
+		SHA256:     "deadbeef",
+		VerifiedAt: time.Now().Truncate(time.Second),
+	}
+	d.Put(want)
+
+	if err := d.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := Load(dbPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	got, ok := loaded.Get(want.Path)
+	if !ok {
+		t.Fatalf("Record for %s not found after reload", want.Path)
+	}
+	if got.Size != want.Size || got.SHA256 != want.SHA256 || !got.ModTime.Equal(want.ModTime) {
+		t.Errorf("Record mismatch after reload. Want: %+v, Got: %+v", want, got)
+	}
+}
+
+func TestLoadMissingFileReturnsEmptyDB(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "does-not-exist.json")
+
+	d, err := Load(dbPath)
+	if err != nil {
+		t.Fatalf("Load of a missing file should not error, got: %v", err)
+	}
+	if len(d.List()) != 0 {
+		t.Errorf("Expected an empty database, got %d records", len(d.List()))
+	}
+}
+
+func TestUnchanged(t *testing.T) {
+	now := time.Now()
+	r := Record{Size: 100, ModTime: now}
+
+	if !r.Unchanged(100, now) {
+		t.Error("Expected Unchanged to be true for identical size and mtime")
+	}
+	if r.Unchanged(200, now) {
+		t.Error("Expected Unchanged to be false for a different size")
+	}
+	if r.Unchanged(100, now.Add(time.Second)) {
+		t.Error("Expected Unchanged to be false for a different mtime")
+	}
+}
+
+func TestSerializerPersistsAllPuts(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "checksums.json")
+	d := New(dbPath)
+
+	s := NewSerializer(d, 2)
+	for i := 0; i < 5; i++ {
+		s.Put(Record{Path: filepath.Join("/data", string(rune('a'+i))), Size: int64(i)})
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	loaded, err := Load(dbPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(loaded.List()) != 5 {
+		t.Errorf("Expected 5 records on disk, got %d", len(loaded.List()))
+	}
+}
@@ -0,0 +1,70 @@
+package db
+
+// Serializer funnels concurrent record updates through a single goroutine,
+// so scan workers never race on the underlying DB, and periodically
+// persists it atomically so a long scan over a TB-scale tree only loses the
+// updates since the last checkpoint if it is interrupted.
+type Serializer struct {
+	db        *DB
+	requests  chan Record
+	done      chan struct{}
+	saveEvery int
+	err       error
+}
+
+// defaultSaveEvery is how many records accumulate between checkpoints when
+// the caller doesn't request a specific cap.
+const defaultSaveEvery = 100
+
+// NewSerializer starts the serializer goroutine for db. maxOutstanding
+// bounds how many record updates may be queued ahead of the serializer
+// (callers block past that point), which in turn bounds how many DB writes
+// a CLI's -jobs worker pool can have in flight at once. A checkpoint is
+// saved to disk every maxOutstanding records.
+func NewSerializer(d *DB, maxOutstanding int) *Serializer {
+	if maxOutstanding <= 0 {
+		maxOutstanding = defaultSaveEvery
+	}
+	s := &Serializer{
+		db:        d,
+		requests:  make(chan Record, maxOutstanding),
+		done:      make(chan struct{}),
+		saveEvery: maxOutstanding,
+	}
+	go s.run()
+	return s
+}
+
+func (s *Serializer) run() {
+	defer close(s.done)
+
+	count := 0
+	for r := range s.requests {
+		s.db.Put(r)
+		count++
+		if count%s.saveEvery == 0 {
+			if err := s.db.Save(); err != nil && s.err == nil {
+				s.err = err
+			}
+		}
+	}
+
+	if err := s.db.Save(); err != nil && s.err == nil {
+		s.err = err
+	}
+}
+
+// Put queues r to be written by the serializer goroutine. It blocks once
+// maxOutstanding updates are already queued.
+func (s *Serializer) Put(r Record) {
+	s.requests <- r
+}
+
+// Close stops accepting new records, waits for the serializer goroutine to
+// drain its queue and checkpoint one last time, and returns the first save
+// error encountered, if any.
+func (s *Serializer) Close() error {
+	close(s.requests)
+	<-s.done
+	return s.err
+}
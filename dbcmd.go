@@ -0,0 +1,250 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/szydell/go-sha256/db"
+)
+
+// defaultDBJobs is the default cap on concurrent hash workers, and on DB
+// writes outstanding ahead of the serializer goroutine, for the database
+// subcommands.
+const defaultDBJobs = defaultWorkers
+
+// runDBVerb dispatches the update, verify, and list database subcommands
+// shared -db/-jobs/-list flags and prints their usage on request.
+func runDBVerb(ctx context.Context, verb string, args []string) int {
+	var dbPath string
+	var jobs = defaultDBJobs
+	var listPath string
+	var roots []string
+
+	i := 0
+	for i < len(args) {
+		arg := args[i]
+		switch arg {
+		case "-h", "-help", "--help":
+			printDBUsage(verb)
+			return 0
+		case "-db":
+			if i+1 >= len(args) {
+				_, _ = fmt.Fprintf(os.Stderr, "Error: -db requires a path\n")
+				return 1
+			}
+			dbPath = args[i+1]
+			i += 2
+		case "-jobs":
+			if i+1 >= len(args) {
+				_, _ = fmt.Fprintf(os.Stderr, "Error: -jobs requires a number\n")
+				return 1
+			}
+			if _, err := fmt.Sscanf(args[i+1], "%d", &jobs); err != nil || jobs <= 0 {
+				_, _ = fmt.Fprintf(os.Stderr, "Error: invalid -jobs value: %s\n", args[i+1])
+				return 1
+			}
+			i += 2
+		case "-list":
+			if i+1 >= len(args) {
+				_, _ = fmt.Fprintf(os.Stderr, "Error: -list requires a file path\n")
+				return 1
+			}
+			listPath = args[i+1]
+			i += 2
+		default:
+			roots = append(roots, arg)
+			i++
+		}
+	}
+
+	if dbPath == "" {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %s requires -db <path.json>\n", verb)
+		return 1
+	}
+
+	database, err := db.Load(dbPath)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	switch verb {
+	case "update":
+		return runDBUpdate(ctx, database, dbPath, roots, listPath, jobs)
+	case "verify":
+		return runDBVerify(ctx, database, jobs)
+	case "list":
+		return runDBList(database)
+	default:
+		_, _ = fmt.Fprintf(os.Stderr, "Error: unknown database command %q\n", verb)
+		return 1
+	}
+}
+
+// collectPaths gathers the files a database subcommand should look at: all
+// regular files under each root directory, plus anything named in a -list
+// manifest.
+func collectPaths(roots []string, listPath string) ([]string, error) {
+	var paths []string
+
+	for _, root := range roots {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.Type().IsRegular() {
+				paths = append(paths, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to walk %s: %w", root, err)
+		}
+	}
+
+	if listPath != "" {
+		files, err := readFileList(listPath)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, files...)
+	}
+
+	return paths, nil
+}
+
+// runDBUpdate walks roots (and/or a -list manifest), recomputing SHA-256
+// only for files whose size or mtime no longer matches the stored record,
+// and persists the result through a serializer goroutine bounded by jobs.
+func runDBUpdate(ctx context.Context, database *db.DB, dbPath string, roots []string, listPath string, jobs int) int {
+	paths, err := collectPaths(roots, listPath)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	var toHash []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Warning: cannot stat %s: %v\n", path, err)
+			continue
+		}
+		if existing, ok := database.Get(path); ok && existing.Unchanged(info.Size(), info.ModTime()) {
+			continue
+		}
+		toHash = append(toHash, path)
+	}
+
+	fmt.Printf("Updating %d of %d files (%d unchanged)...\n", len(toHash), len(paths), len(paths)-len(toHash))
+
+	serializer := db.NewSerializer(database, jobs)
+	processor := NewFileProcessor(jobs, []string{defaultAlgo})
+
+	failed := 0
+	for result := range processor.ProcessFiles(ctx, toHash) {
+		if result.Error != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Error: %s - %v\n", result.Path, result.Error)
+			failed++
+			continue
+		}
+		serializer.Put(db.Record{
+			Path:       result.Path,
+			Size:       result.Size,
+			ModTime:    result.ModTime,
+			SHA256:     result.Hashes[defaultAlgo],
+			VerifiedAt: time.Now(),
+		})
+	}
+
+	if err := serializer.Close(); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: failed to save database: %v\n", err)
+		return 1
+	}
+
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// runDBVerify re-hashes every file already tracked in the database and
+// reports any digest that no longer matches the stored one as potential
+// corruption.
+func runDBVerify(ctx context.Context, database *db.DB, jobs int) int {
+	records := database.List()
+	paths := make([]string, 0, len(records))
+	byPath := make(map[string]db.Record, len(records))
+	for _, r := range records {
+		paths = append(paths, r.Path)
+		byPath[r.Path] = r
+	}
+
+	processor := NewFileProcessor(jobs, []string{defaultAlgo})
+	serializer := db.NewSerializer(database, jobs)
+
+	corrupted := 0
+	for result := range processor.ProcessFiles(ctx, paths) {
+		record := byPath[result.Path]
+		if result.Error != nil {
+			fmt.Printf("%s: FAILED (%v)\n", result.Path, result.Error)
+			corrupted++
+			continue
+		}
+
+		got := result.Hashes[defaultAlgo]
+		if got != record.SHA256 {
+			fmt.Printf("%s: CORRUPT (expected %s, got %s)\n", result.Path, record.SHA256, got)
+			corrupted++
+			continue
+		}
+
+		fmt.Printf("%s: OK\n", result.Path)
+		record.VerifiedAt = time.Now()
+		serializer.Put(record)
+	}
+
+	if err := serializer.Close(); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: failed to save database: %v\n", err)
+		return 1
+	}
+
+	if corrupted > 0 {
+		return 1
+	}
+	return 0
+}
+
+// runDBList prints every record currently stored in the database.
+func runDBList(database *db.DB) int {
+	for _, r := range database.List() {
+		fmt.Printf("%s  %s  %s  %s\n", r.SHA256, formatSize(r.Size), r.ModTime.Format(time.RFC3339), r.Path)
+	}
+	return 0
+}
+
+// printDBUsage prints usage for the update/verify/list subcommands.
+func printDBUsage(verb string) {
+	_, _ = fmt.Fprintf(os.Stderr, `Usage: %s %s -db <path.json> [options] [dir ...]
+
+Maintain a persistent checksum database for incremental bit-rot scanning.
+
+Options:
+  -db <path>       Path to the checksum database (JSON), required
+  -jobs <num>      Concurrent hash workers, also bounding outstanding DB
+                   writes (default: %d)
+  -list <file>     Read file paths from a text file, in addition to any
+                   directories given on the command line
+
+Verbs:
+  update           Recompute digests only for files whose size/mtime
+                   changed since the last update
+  verify           Recompute every tracked digest and report mismatches
+  list             Print the current contents of the database
+
+`, os.Args[0], verb, defaultDBJobs)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/szydell/go-sha256/db"
+)
+
+func TestRunDBUpdateSkipsUnchangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(t.TempDir(), "checksums.json")
+
+	filePath := filepath.Join(dir, "a.txt")
+	if err := os.WriteFile(filePath, []byte("hello"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	database, err := db.Load(dbPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if code := runDBUpdate(context.Background(), database, dbPath, []string{dir}, "", 2); code != 0 {
+		t.Fatalf("first runDBUpdate returned %d", code)
+	}
+
+	record, ok := database.Get(filePath)
+	if !ok {
+		t.Fatalf("Expected a record for %s", filePath)
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if record.Size != info.Size() || !record.ModTime.Equal(info.ModTime()) {
+		t.Errorf("Expected a consistent size/mtime snapshot, got size=%d mtime=%v, stat reports size=%d mtime=%v",
+			record.Size, record.ModTime, info.Size(), info.ModTime())
+	}
+
+	firstVerifiedAt := record.VerifiedAt
+
+	// A second update over the same unchanged file should not re-hash it,
+	// so the stored VerifiedAt timestamp must not move.
+	if code := runDBUpdate(context.Background(), database, dbPath, []string{dir}, "", 2); code != 0 {
+		t.Fatalf("second runDBUpdate returned %d", code)
+	}
+	record, _ = database.Get(filePath)
+	if !record.VerifiedAt.Equal(firstVerifiedAt) {
+		t.Errorf("Expected unchanged file to be skipped on re-update, VerifiedAt moved from %v to %v", firstVerifiedAt, record.VerifiedAt)
+	}
+}
+
+func TestRunDBVerifyDetectsCorruption(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(t.TempDir(), "checksums.json")
+
+	filePath := filepath.Join(dir, "a.txt")
+	if err := os.WriteFile(filePath, []byte("hello"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	database, err := db.Load(dbPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if code := runDBUpdate(context.Background(), database, dbPath, []string{dir}, "", 2); code != 0 {
+		t.Fatalf("runDBUpdate returned %d", code)
+	}
+
+	if err := os.WriteFile(filePath, []byte("tampered"), 0644); err != nil {
+		t.Fatalf("Failed to tamper with test file: %v", err)
+	}
+
+	if code := runDBVerify(context.Background(), database, 2); code == 0 {
+		t.Error("Expected runDBVerify to report corruption with a non-zero exit code")
+	}
+}
@@ -2,15 +2,28 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
 	"crypto/sha256"
+	"crypto/sha512"
 	"fmt"
+	"hash"
 	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/sha3"
+	"lukechampine.com/blake3"
 )
 
 const (
@@ -18,35 +31,132 @@ const (
 	bufferSize = 64 * 1024
 	// Default number of workers for concurrent processing
 	defaultWorkers = 4
+	// Default algorithm used when none is requested explicitly
+	defaultAlgo = "sha256"
+	// chunkQueueDepth bounds how many chunks a hasher goroutine may lag behind the reader
+	chunkQueueDepth = 4
+	// treeThreshold is the minimum file size, in bytes, for which Tree mode
+	// computes a Merkle tree root alongside the flat digests.
+	treeThreshold = 1 << 30 // 1 GiB
+	// treeChunkSize is the fixed chunk size used to build the Merkle tree.
+	treeChunkSize = 4 << 20 // 4 MiB
 )
 
-// FileResult represents the result of SHA256 calculation for a single file
+// supportedAlgorithms maps an algorithm name, as accepted by the -algos flag,
+// to a constructor for the corresponding hash.Hash implementation.
+var supportedAlgorithms = map[string]func() hash.Hash{
+	"md5":      md5.New,
+	"sha1":     sha1.New,
+	"sha256":   sha256.New,
+	"sha512":   sha512.New,
+	"sha3-256": sha3.New256,
+	"blake2b":  newBlake2b512,
+	"blake3":   newBlake3,
+}
+
+func newBlake2b512() hash.Hash {
+	h, _ := blake2b.New512(nil) // nil key never errors
+	return h
+}
+
+func newBlake3() hash.Hash {
+	return blake3.New(32, nil)
+}
+
+// FileResult represents the result of hashing a single file with one or more algorithms
 type FileResult struct {
 	Path     string
-	Hash     string
+	Hashes   map[string]string
 	Size     int64
+	ModTime  time.Time
 	Duration time.Duration
 	Error    error
+
+	// TreeRoot and ChunkHashes are set when the processor's Tree mode
+	// hashed this file as a Merkle tree instead of (or alongside) the flat
+	// digests in Hashes; see computeTreeRoot for the exact construction.
+	TreeRoot    string
+	ChunkHashes []string
 }
 
-// FileProcessor handles SHA256 calculation for files
+// ProgressFunc is called periodically while a file is being hashed, so a
+// caller can render a per-file percentage or aggregate throughput for very
+// large (multi-TiB) inputs. bytesRead is cumulative for the file; totalBytes
+// is its size as reported by Stat.
+type ProgressFunc func(path string, bytesRead, totalBytes int64)
+
+const (
+	// progressByteInterval is the minimum number of bytes hashed between
+	// ProgressFunc calls for a single file.
+	progressByteInterval = 64 * 1024 * 1024
+	// progressTimeInterval is the minimum time between ProgressFunc calls
+	// for a single file, regardless of how much data has moved.
+	progressTimeInterval = 500 * time.Millisecond
+)
+
+// FileProcessor handles checksum calculation for files
 type FileProcessor struct {
 	workerCount int
+	algorithms  []string
+
+	// Progress, if set, is invoked while hashing large files. It may be
+	// called concurrently from multiple worker goroutines.
+	Progress ProgressFunc
+
+	// Tree, if set, additionally computes a Merkle tree root (see
+	// computeTreeRoot) for every file at least treeThreshold bytes large.
+	Tree bool
 }
 
 // NewFileProcessor creates a new file processor with the specified worker count
-func NewFileProcessor(workers int) *FileProcessor {
+// and set of hash algorithms. An empty algos defaults to SHA-256.
+func NewFileProcessor(workers int, algos []string) *FileProcessor {
 	if workers <= 0 {
 		workers = runtime.NumCPU()
 		if workers > defaultWorkers {
 			workers = defaultWorkers
 		}
 	}
-	return &FileProcessor{workerCount: workers}
+	if len(algos) == 0 {
+		algos = []string{defaultAlgo}
+	}
+	return &FileProcessor{workerCount: workers, algorithms: algos}
 }
 
-// calculateSHA256 calculates the SHA256 hash of a single file using a memory-efficient approach
-func (fp *FileProcessor) calculateSHA256(filePath string) FileResult {
+// chunkPool recycles read buffers across calculate() calls so the fan-out
+// writer below doesn't allocate on every 64KiB read.
+var chunkPool = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, bufferSize)
+		return &b
+	},
+}
+
+// hashChunk is one buffer's worth of file data shared, without copying,
+// between every hasher goroutine that still needs to consume it. Once the
+// last hasher has written it, the backing buffer is returned to chunkPool.
+type hashChunk struct {
+	data []byte
+	buf  *[]byte
+	refs int32
+}
+
+func (c *hashChunk) release() {
+	if atomic.AddInt32(&c.refs, -1) == 0 {
+		chunkPool.Put(c.buf)
+	}
+}
+
+// calculate computes the digest of filePath for every algorithm in algos in a
+// single pass over the file. A reader goroutine reads 64KiB chunks and hands
+// each one, without copying, to one goroutine per hash.Hash; a sync.WaitGroup
+// gates the final Sum once every hasher has drained its channel. This avoids
+// the serialization of io.MultiWriter when slower algorithms (SHA-512,
+// BLAKE2b) would otherwise bottleneck on the fastest one.
+//
+// ctx is checked between chunk reads so a cancelled context abandons a
+// partially-hashed file promptly instead of reading it to completion.
+func (fp *FileProcessor) calculate(ctx context.Context, filePath string, algos []string) FileResult {
 	startTime := time.Now()
 
 	result := FileResult{
@@ -67,44 +177,228 @@ func (fp *FileProcessor) calculateSHA256(filePath string) FileResult {
 		return result
 	}
 	result.Size = stat.Size()
+	result.ModTime = stat.ModTime()
+
+	// Validate every algorithm before starting any hasher goroutine: once a
+	// goroutine is reading from its channel, bailing out early would leave
+	// it blocked on "for c := range ch" forever since nothing would ever
+	// close that channel.
+	for _, algo := range algos {
+		if _, ok := supportedAlgorithms[algo]; !ok {
+			result.Error = fmt.Errorf("unsupported algorithm: %s", algo)
+			return result
+		}
+	}
+
+	// De-duplicate: hashers/channels are keyed by algo name, so a repeated
+	// name (algos is free-text from -algos) would otherwise overwrite an
+	// earlier hasher's channel in the map, leaving that hasher's goroutine
+	// reading a channel nobody will ever send to or close and wg.Wait()
+	// blocked forever.
+	seen := make(map[string]bool, len(algos))
+	dedupedAlgos := make([]string, 0, len(algos))
+	for _, algo := range algos {
+		if seen[algo] {
+			continue
+		}
+		seen[algo] = true
+		dedupedAlgos = append(dedupedAlgos, algo)
+	}
 
-	// Create SHA256 hasher
-	hasher := sha256.New()
+	hashers := make(map[string]hash.Hash, len(dedupedAlgos))
+	channels := make(map[string]chan *hashChunk, len(dedupedAlgos))
+
+	var wg sync.WaitGroup
+	for _, algo := range dedupedAlgos {
+		h := supportedAlgorithms[algo]()
+		ch := make(chan *hashChunk, chunkQueueDepth)
+		hashers[algo] = h
+		channels[algo] = ch
+
+		wg.Add(1)
+		go func(h hash.Hash, ch chan *hashChunk) {
+			defer wg.Done()
+			for c := range ch {
+				h.Write(c.data)
+				c.release()
+			}
+		}(h, ch)
+	}
 
-	// Use a buffer to read a file in chunks for memory efficiency with large files
-	buffer := make([]byte, bufferSize)
+	// Read the file once, fanning each chunk out to every hasher's channel.
+	var readErr error
+	var bytesRead int64
+	var sinceProgress int64
+	lastProgress := time.Now()
 
 	for {
-		n, err := file.Read(buffer)
+		if err := ctx.Err(); err != nil {
+			readErr = err
+			break
+		}
+
+		bufPtr := chunkPool.Get().(*[]byte)
+		buf := *bufPtr
+
+		n, err := file.Read(buf)
 		if n > 0 {
-			hasher.Write(buffer[:n])
+			c := &hashChunk{data: buf[:n], buf: bufPtr, refs: int32(len(dedupedAlgos))}
+			for _, ch := range channels {
+				ch <- c
+			}
+
+			bytesRead += int64(n)
+			sinceProgress += int64(n)
+			if fp.Progress != nil && (sinceProgress >= progressByteInterval || time.Since(lastProgress) >= progressTimeInterval) {
+				fp.Progress(filePath, bytesRead, result.Size)
+				sinceProgress = 0
+				lastProgress = time.Now()
+			}
+		} else {
+			chunkPool.Put(bufPtr)
 		}
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
-			result.Error = fmt.Errorf("failed to read file: %w", err)
+			readErr = err
+			break
+		}
+	}
+
+	for _, ch := range channels {
+		close(ch)
+	}
+	wg.Wait()
+
+	if readErr != nil {
+		result.Error = fmt.Errorf("failed to read file: %w", readErr)
+		return result
+	}
+
+	if fp.Progress != nil {
+		fp.Progress(filePath, bytesRead, result.Size)
+	}
+
+	result.Hashes = make(map[string]string, len(dedupedAlgos))
+	for algo, h := range hashers {
+		result.Hashes[algo] = fmt.Sprintf("%x", h.Sum(nil))
+	}
+
+	if fp.Tree && result.Size >= treeThreshold {
+		root, chunkHashes, err := fp.computeTreeRoot(ctx, file, result.Size)
+		if err != nil {
+			result.Error = fmt.Errorf("failed to compute tree root: %w", err)
 			return result
 		}
+		result.TreeRoot = root
+		result.ChunkHashes = chunkHashes
 	}
 
-	// Get the final hash
-	hashBytes := hasher.Sum(nil)
-	result.Hash = fmt.Sprintf("%x", hashBytes)
 	result.Duration = time.Since(startTime)
 
 	return result
 }
 
-// ProcessFiles processes multiple files concurrently
-func (fp *FileProcessor) ProcessFiles(filePaths []string) []FileResult {
-	if len(filePaths) == 0 {
-		return []FileResult{}
+// computeTreeRoot hashes file in fixed treeChunkSize chunks, read
+// independently via ReadAt (no shared file cursor) so chunks can be hashed in
+// parallel across fp.workerCount goroutines, then hashes the concatenation of
+// the raw (non-hex) chunk digests, in ascending chunk order, to produce a
+// single root digest. This mirrors the flat-tree construction used by
+// BitTorrent v2 and BAO, so the root can be reproduced independently of this
+// tool given only the file and the parameters documented below.
+//
+// Construction:
+//
+//   - Chunk size: 4 MiB (4*1024*1024 bytes), fixed. The file is split into
+//     ceil(size/4MiB) chunks; the final chunk holds whatever bytes remain and
+//     is hashed as-is, without zero-padding.
+//   - Chunk digest: SHA-256 of the chunk's raw bytes.
+//   - Root digest: SHA-256 of the concatenation of every chunk digest (32
+//     raw bytes each, not hex), in ascending chunk-index order. No length
+//     prefix, suffix, or separator is included between digests.
+//   - A zero-length file is treated as a single empty chunk, so its root is
+//     SHA-256(SHA256("")).
+func (fp *FileProcessor) computeTreeRoot(ctx context.Context, file *os.File, size int64) (root string, chunkHashes []string, err error) {
+	numChunks := int((size + treeChunkSize - 1) / treeChunkSize)
+	if numChunks == 0 {
+		numChunks = 1
 	}
 
-	// Create channels for work distribution
-	jobs := make(chan string, len(filePaths))
-	results := make(chan FileResult, len(filePaths))
+	digests := make([][sha256.Size]byte, numChunks)
+
+	jobs := make(chan int, numChunks)
+	for i := 0; i < numChunks; i++ {
+		jobs <- i
+	}
+	close(jobs)
+
+	workers := fp.workerCount
+	if workers > numChunks {
+		workers = numChunks
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			buf := make([]byte, treeChunkSize)
+			for idx := range jobs {
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					errs <- ctxErr
+					return
+				}
+
+				offset := int64(idx) * treeChunkSize
+				chunkLen := treeChunkSize
+				if remaining := size - offset; remaining < int64(chunkLen) {
+					chunkLen = int(remaining)
+				}
+
+				n, readErr := file.ReadAt(buf[:chunkLen], offset)
+				if readErr != nil && readErr != io.EOF {
+					errs <- fmt.Errorf("failed to read chunk %d: %w", idx, readErr)
+					return
+				}
+				digests[idx] = sha256.Sum256(buf[:n])
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for e := range errs {
+		if err == nil {
+			err = e
+		}
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	chunkHashes = make([]string, numChunks)
+	concat := make([]byte, 0, numChunks*sha256.Size)
+	for i, d := range digests {
+		chunkHashes[i] = fmt.Sprintf("%x", d)
+		concat = append(concat, d[:]...)
+	}
+
+	rootSum := sha256.Sum256(concat)
+	return fmt.Sprintf("%x", rootSum), chunkHashes, nil
+}
+
+// ProcessFiles processes files concurrently across the worker pool,
+// returning results on a channel as each file finishes rather than
+// buffering the whole batch, so callers driving very large inputs (e.g. a
+// 100k-entry checksum manifest) don't have to hold every result in memory
+// at once. Cancelling ctx stops workers from starting new files and
+// abandons any file already being hashed; already-completed results remain
+// available on the returned channel, which is always closed once every
+// worker has exited.
+func (fp *FileProcessor) ProcessFiles(ctx context.Context, filePaths []string) <-chan FileResult {
+	jobs := make(chan string, fp.workerCount)
+	results := make(chan FileResult, fp.workerCount)
 
 	// Start workers
 	var wg sync.WaitGroup
@@ -113,28 +407,42 @@ func (fp *FileProcessor) ProcessFiles(filePaths []string) []FileResult {
 		go func() {
 			defer wg.Done()
 			for filePath := range jobs {
-				results <- fp.calculateSHA256(filePath)
+				results <- fp.calculate(ctx, filePath, fp.algorithms)
 			}
 		}()
 	}
 
-	// Send jobs
+	// Send jobs, stopping early if ctx is cancelled so the sender goroutine
+	// doesn't leak blocked on a jobs channel nobody is draining anymore.
 	go func() {
+		defer close(jobs)
 		for _, filePath := range filePaths {
-			jobs <- filePath
+			select {
+			case jobs <- filePath:
+			case <-ctx.Done():
+				return
+			}
 		}
-		close(jobs)
 	}()
 
-	// Wait for all workers to finish
+	// Close results once all workers are done
 	go func() {
 		wg.Wait()
 		close(results)
 	}()
 
-	// Collect results
+	return results
+}
+
+// ProcessFilesBatch runs ProcessFiles and collects every result before
+// returning, for callers that want the whole batch at once.
+func (fp *FileProcessor) ProcessFilesBatch(ctx context.Context, filePaths []string) []FileResult {
+	if len(filePaths) == 0 {
+		return []FileResult{}
+	}
+
 	var allResults []FileResult
-	for result := range results {
+	for result := range fp.ProcessFiles(ctx, filePaths) {
 		allResults = append(allResults, result)
 	}
 
@@ -166,6 +474,177 @@ func readFileList(listPath string) ([]string, error) {
 	return files, nil
 }
 
+// checksumEntry is one parsed line of a checksum manifest: the algorithm
+// and expected hex digest for a path.
+type checksumEntry struct {
+	path string
+	algo string
+	hash string
+}
+
+// bsdTagPattern matches the BSD/shasum "tag" format, e.g.
+// "SHA256 (path/to/file) = <hex>".
+var bsdTagPattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9_-]*) \((.*)\) = ([0-9a-fA-F]+)$`)
+
+// algoNameForHashLen guesses the algorithm of a coreutils-format checksum
+// line from the length of its hex digest, since that format doesn't name
+// the algorithm explicitly (each coreutils tool, e.g. sha256sum, only ever
+// emits its own hash length).
+func algoNameForHashLen(n int) string {
+	switch n {
+	case 32:
+		return "md5"
+	case 40:
+		return "sha1"
+	case 64:
+		return "sha256"
+	case 128:
+		return "sha512"
+	default:
+		return ""
+	}
+}
+
+func isHexDigest(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if !((c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseChecksumLine parses one line of a checksum manifest in any of the
+// three formats GNU/BSD tools emit:
+//
+//	<hex>  <path>     coreutils text mode (two spaces)
+//	<hex> *<path>     coreutils binary mode
+//	ALGO (<path>) = <hex>   BSD/shasum tag format
+func parseChecksumLine(line string) (checksumEntry, error) {
+	if m := bsdTagPattern.FindStringSubmatch(line); m != nil {
+		return checksumEntry{algo: strings.ToLower(m[1]), path: m[2], hash: strings.ToLower(m[3])}, nil
+	}
+
+	idx := strings.IndexByte(line, ' ')
+	if idx <= 0 || idx+1 >= len(line) {
+		return checksumEntry{}, fmt.Errorf("malformed checksum line: %q", line)
+	}
+
+	hexDigest := line[:idx]
+	rest := line[idx+1:]
+
+	var path string
+	switch {
+	case strings.HasPrefix(rest, "*"):
+		path = rest[1:]
+	case strings.HasPrefix(rest, " "):
+		path = rest[1:]
+	default:
+		return checksumEntry{}, fmt.Errorf("malformed checksum line: %q", line)
+	}
+
+	if !isHexDigest(hexDigest) || path == "" {
+		return checksumEntry{}, fmt.Errorf("malformed checksum line: %q", line)
+	}
+
+	algo := algoNameForHashLen(len(hexDigest))
+	if algo == "" {
+		return checksumEntry{}, fmt.Errorf("unrecognized digest length %d in line: %q", len(hexDigest), line)
+	}
+
+	return checksumEntry{algo: algo, path: path, hash: strings.ToLower(hexDigest)}, nil
+}
+
+// readChecksumFile parses every checksum entry out of a manifest file,
+// skipping blank lines and "#"-prefixed comments.
+func readChecksumFile(manifestPath string) ([]checksumEntry, error) {
+	file, err := os.Open(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open checksum file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var entries []checksumEntry
+	scanner := bufio.NewScanner(file)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entry, err := parseChecksumLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", manifestPath, lineNum, err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading checksum file: %w", err)
+	}
+
+	return entries, nil
+}
+
+// verifyChecksums re-hashes every file named in a checksum manifest and
+// prints "path: OK" or "path: FAILED" as each result arrives, streaming
+// through the worker pool so manifests with tens of thousands of entries
+// never need to be held in memory as a whole batch of results. It returns
+// the number of entries that did not match (or failed to hash).
+//
+// Paths are grouped and hashed one algorithm at a time, rather than hashing
+// every file with the union of every algorithm seen in the manifest: a
+// manifest entry for an algorithm this tool doesn't implement (or a typo)
+// would otherwise make calculate() reject the whole batch, failing files
+// that have nothing wrong with them.
+func verifyChecksums(ctx context.Context, manifestPath string, workers int) (int, error) {
+	entries, err := readChecksumFile(manifestPath)
+	if err != nil {
+		return 0, err
+	}
+	if len(entries) == 0 {
+		return 0, fmt.Errorf("no checksum entries found in %s", manifestPath)
+	}
+
+	// Keyed by algo first, then path: a manifest can name more than one
+	// algorithm for the same path (e.g. a combined SHA256SUMS+MD5SUMS
+	// manifest), and a map keyed by path alone would let the last entry
+	// parsed for that path silently overwrite the earlier one(s).
+	hashesByAlgo := make(map[string]map[string]string)
+	pathsByAlgo := make(map[string][]string)
+	for _, entry := range entries {
+		if hashesByAlgo[entry.algo] == nil {
+			hashesByAlgo[entry.algo] = make(map[string]string)
+		}
+		hashesByAlgo[entry.algo][entry.path] = entry.hash
+		pathsByAlgo[entry.algo] = append(pathsByAlgo[entry.algo], entry.path)
+	}
+
+	var mismatches int
+	for algo, paths := range pathsByAlgo {
+		processor := NewFileProcessor(workers, []string{algo})
+		expected := hashesByAlgo[algo]
+		for result := range processor.ProcessFiles(ctx, paths) {
+			switch {
+			case result.Error != nil:
+				mismatches++
+				fmt.Printf("%s: FAILED\n", result.Path)
+			case !strings.EqualFold(result.Hashes[algo], expected[result.Path]):
+				mismatches++
+				fmt.Printf("%s: FAILED\n", result.Path)
+			default:
+				fmt.Printf("%s: OK\n", result.Path)
+			}
+		}
+	}
+
+	return mismatches, nil
+}
+
 // formatSize formats file size in human-readable format
 func formatSize(bytes int64) string {
 	const unit = 1024
@@ -184,36 +663,82 @@ func formatSize(bytes int64) string {
 func printUsage() {
 	_, _ = fmt.Fprintf(os.Stderr, `Usage: %s [options] <file1> [file2] ...
        %s [options] -list <file_list.txt>
+       %s update|verify|list -db <path.json> [options] [dir ...]
+
+Calculate file checksums, optimized for large files up to 5TiB.
 
-Calculate SHA256 checksums for files, optimized for large files up to 5TiB.
+The update/verify/list subcommands maintain a persistent checksum database
+(-db path.json) for incremental bit-rot scanning over large trees; run
+"%s update -h" for their options.
 
 Options:
   -list <file>     Read file paths from a text file (one per line)
   -workers <num>   Number of concurrent workers (default: %d, max: CPU cores)
+  -algos <list>    Comma-separated algorithms to compute per file (default: %s)
+                   Supported: %s
+  -check <file>    Verify files against a checksum manifest (coreutils
+                   "sha256sum -c" text/binary format or BSD tag format)
+                   instead of hashing
+  -tag, -bsd       Print BSD-tagged lines ("ALGO (path) = hex") instead of
+                   the default summary format
+  -tree            Additionally compute a Merkle tree root (4MiB chunks,
+                   hashed in parallel) for files at least %s
   -h, -help        Show this help message
 
 Examples:
   %s file1.txt file2.bin
   %s -list files.txt
   %s -workers 8 largefile.iso
-  
+  %s -algos sha256,sha512,blake3 largefile.iso
+  %s -check SHA256SUMS
+  %s -tag file1.txt > SHA256SUMS
+
 File list format (files.txt):
   /path/to/file1.txt
   /path/to/file2.bin
   # Comments starting with # are ignored
   /path/to/file3.dat
 
-`, os.Args[0], os.Args[0], defaultWorkers, os.Args[0], os.Args[0], os.Args[0])
+`, os.Args[0], os.Args[0], os.Args[0], os.Args[0], defaultWorkers, defaultAlgo, strings.Join(sortedAlgoNames(), ", "), formatSize(treeThreshold),
+		os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0])
 }
 
+// sortedAlgoNames returns the supported algorithm names in a stable order,
+// for display in the usage text.
+func sortedAlgoNames() []string {
+	names := make([]string, 0, len(supportedAlgorithms))
+	for name := range supportedAlgorithms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// dbVerbs are the database subcommands handled by runDBVerb instead of the
+// default ad hoc hashing mode.
+var dbVerbs = map[string]bool{"update": true, "verify": true, "list": true}
+
 func main() {
 	if len(os.Args) < 2 {
 		printUsage()
 		os.Exit(1)
 	}
 
+	// Cancel the context on SIGINT so an interrupted multi-TiB hash abandons
+	// its in-flight files and workers exit promptly instead of leaking.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if dbVerbs[os.Args[1]] {
+		os.Exit(runDBVerb(ctx, os.Args[1], os.Args[2:]))
+	}
+
 	var filePaths []string
 	var workers = defaultWorkers
+	var algos []string
+	var checkManifest string
+	var tagFormat bool
+	var treeMode bool
 
 	// Parse command line arguments
 	i := 1
@@ -224,6 +749,33 @@ func main() {
 		case "-h", "-help", "--help":
 			printUsage()
 			os.Exit(0)
+		case "-tag", "-bsd":
+			tagFormat = true
+			i++
+		case "-tree":
+			treeMode = true
+			i++
+		case "-check":
+			if i+1 >= len(os.Args) {
+				_, _ = fmt.Fprintf(os.Stderr, "Error: -check requires a checksum file path\n")
+				os.Exit(1)
+			}
+			checkManifest = os.Args[i+1]
+			i += 2
+		case "-algos":
+			if i+1 >= len(os.Args) {
+				_, _ = fmt.Fprintf(os.Stderr, "Error: -algos requires a comma-separated list\n")
+				os.Exit(1)
+			}
+			for _, algo := range strings.Split(os.Args[i+1], ",") {
+				algo = strings.ToLower(strings.TrimSpace(algo))
+				if _, ok := supportedAlgorithms[algo]; !ok {
+					_, _ = fmt.Fprintf(os.Stderr, "Error: unsupported algorithm %q (supported: %s)\n", algo, strings.Join(sortedAlgoNames(), ", "))
+					os.Exit(1)
+				}
+				algos = append(algos, algo)
+			}
+			i += 2
 		case "-list":
 			if i+1 >= len(os.Args) {
 				_, _ = fmt.Fprintf(os.Stderr, "Error: -list requires a file path\n")
@@ -261,6 +813,18 @@ func main() {
 		}
 	}
 
+	if checkManifest != "" {
+		mismatches, err := verifyChecksums(ctx, checkManifest, workers)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if mismatches > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
 	if len(filePaths) == 0 {
 		_, _ = fmt.Fprintf(os.Stderr, "Error: no files specified\n")
 		printUsage()
@@ -283,12 +847,28 @@ func main() {
 	}
 
 	// Create a processor and calculate checksums
-	processor := NewFileProcessor(workers)
+	processor := NewFileProcessor(workers, algos)
+	processor.Tree = treeMode
+
+	// In -tag mode stdout must stay pipeable straight into a checksum
+	// manifest, so progress and summary go to stderr instead.
+	progressOut := os.Stdout
+	if tagFormat {
+		progressOut = os.Stderr
+	}
+
+	processor.Progress = func(path string, bytesRead, totalBytes int64) {
+		pct := 100.0
+		if totalBytes > 0 {
+			pct = float64(bytesRead) / float64(totalBytes) * 100
+		}
+		fmt.Fprintf(progressOut, "  %s: %.1f%% (%s/%s)\n", filepath.Base(path), pct, formatSize(bytesRead), formatSize(totalBytes))
+	}
 
-	fmt.Printf("Processing %d files with %d workers...\n\n", len(validFiles), processor.workerCount)
+	fmt.Fprintf(progressOut, "Processing %d files with %d workers...\n\n", len(validFiles), processor.workerCount)
 
 	startTime := time.Now()
-	results := processor.ProcessFiles(validFiles)
+	results := processor.ProcessFilesBatch(ctx, validFiles)
 	totalTime := time.Since(startTime)
 
 	// Print results
@@ -297,22 +877,46 @@ func main() {
 
 	for _, result := range results {
 		if result.Error != nil {
-			fmt.Printf("ERROR: %s - %v\n", result.Path, result.Error)
+			fmt.Fprintf(progressOut, "ERROR: %s - %v\n", result.Path, result.Error)
+			continue
+		}
+
+		algoNames := make([]string, 0, len(result.Hashes))
+		for algo := range result.Hashes {
+			algoNames = append(algoNames, algo)
+		}
+		sort.Strings(algoNames)
+
+		if tagFormat {
+			for _, algo := range algoNames {
+				fmt.Printf("%s (%s) = %s\n", strings.ToUpper(algo), result.Path, result.Hashes[algo])
+			}
+			if result.TreeRoot != "" {
+				fmt.Printf("TREE256 (%s) = %s\n", result.Path, result.TreeRoot)
+			}
 		} else {
-			fmt.Printf("%s  %s (%s, %v)\n", result.Hash, filepath.Base(result.Path), formatSize(result.Size), result.Duration)
-			totalSize += result.Size
-			successCount++
+			digests := make([]string, 0, len(algoNames))
+			for _, algo := range algoNames {
+				digests = append(digests, fmt.Sprintf("%s:%s", algo, result.Hashes[algo]))
+			}
+			if result.TreeRoot != "" {
+				digests = append(digests, fmt.Sprintf("tree256:%s", result.TreeRoot))
+			}
+			fmt.Printf("%s  %s (%s, %v)\n", strings.Join(digests, " "), filepath.Base(result.Path), formatSize(result.Size), result.Duration)
 		}
+
+		totalSize += result.Size
+		successCount++
 	}
 
 	// Print summary
-	fmt.Printf("\nSummary:\n")
-	fmt.Printf("  Files processed: %d/%d\n", successCount, len(validFiles))
-	fmt.Printf("  Total size: %s\n", formatSize(totalSize))
-	fmt.Printf("  Total time: %v\n", totalTime)
+	fmt.Fprintf(progressOut, "\nSummary:\n")
+	fmt.Fprintf(progressOut, "  Files processed: %d/%d\n", successCount, len(validFiles))
+	fmt.Fprintf(progressOut, "  Total size: %s\n", formatSize(totalSize))
+	fmt.Fprintf(progressOut, "  Total time: %v\n", totalTime)
 	if totalTime > 0 && totalSize > 0 {
 		throughput := float64(totalSize) / totalTime.Seconds() / 1024 / 1024
-		fmt.Printf("  Throughput: %.2f MB/s\n", throughput)
+		fmt.Fprintf(progressOut, "  Throughput: %.2f MB/s\n", throughput)
 	}
 
 	// Exit with error code if any files failed
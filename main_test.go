@@ -1,56 +1,284 @@
 package main
 
 import (
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
 	"crypto/sha256"
+	"crypto/sha512"
 	"fmt"
+	"hash"
+	"io"
 	"os"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/sha3"
+	"lukechampine.com/blake3"
 )
 
 func TestCalculateSHA256(t *testing.T) {
-	processor := NewFileProcessor(2)
-	
+	processor := NewFileProcessor(2, nil)
+
 	// Create a temporary test file
 	tempFile := "/tmp/test_sha256.txt"
 	content := "Hello, World!"
-	
+
 	err := os.WriteFile(tempFile, []byte(content), 0644)
 	if err != nil {
 		t.Fatalf("Failed to create test file: %v", err)
 	}
 	defer os.Remove(tempFile)
-	
+
 	// Calculate expected hash
 	hasher := sha256.New()
 	hasher.Write([]byte(content))
 	expectedHash := fmt.Sprintf("%x", hasher.Sum(nil))
-	
+
 	// Test our implementation
-	result := processor.calculateSHA256(tempFile)
-	
+	result := processor.calculate(context.Background(), tempFile, []string{"sha256"})
+
 	if result.Error != nil {
 		t.Fatalf("Error calculating SHA256: %v", result.Error)
 	}
-	
-	if result.Hash != expectedHash {
-		t.Errorf("Hash mismatch. Expected: %s, Got: %s", expectedHash, result.Hash)
+
+	if result.Hashes["sha256"] != expectedHash {
+		t.Errorf("Hash mismatch. Expected: %s, Got: %s", expectedHash, result.Hashes["sha256"])
 	}
-	
+
 	if result.Size != int64(len(content)) {
 		t.Errorf("Size mismatch. Expected: %d, Got: %d", len(content), result.Size)
 	}
-	
+
+	if result.ModTime.IsZero() {
+		t.Error("Expected ModTime to be populated")
+	}
+
 	if result.Path != tempFile {
 		t.Errorf("Path mismatch. Expected: %s, Got: %s", tempFile, result.Path)
 	}
-	
+
 	if result.Duration <= 0 {
 		t.Error("Duration should be positive")
 	}
 }
 
+func TestCalculateMultipleAlgorithms(t *testing.T) {
+	tempFile := "/tmp/test_multi_algo.txt"
+	content := "The quick brown fox jumps over the lazy dog"
+
+	if err := os.WriteFile(tempFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	defer os.Remove(tempFile)
+
+	blake2bHash, err := blake2b.New512(nil)
+	if err != nil {
+		t.Fatalf("Failed to create blake2b hasher: %v", err)
+	}
+	blake2bHash.Write([]byte(content))
+
+	blake3Hash := blake3.New(32, nil)
+	blake3Hash.Write([]byte(content))
+
+	sha3Hash := sha3.New256()
+	sha3Hash.Write([]byte(content))
+
+	md5Hash := md5.New()
+	md5Hash.Write([]byte(content))
+
+	sha1Hash := sha1.New()
+	sha1Hash.Write([]byte(content))
+
+	sha512Hash := sha512.New()
+	sha512Hash.Write([]byte(content))
+
+	expected := map[string]string{
+		"md5":      fmt.Sprintf("%x", md5Hash.Sum(nil)),
+		"sha1":     fmt.Sprintf("%x", sha1Hash.Sum(nil)),
+		"sha512":   fmt.Sprintf("%x", sha512Hash.Sum(nil)),
+		"sha3-256": fmt.Sprintf("%x", sha3Hash.Sum(nil)),
+		"blake2b":  fmt.Sprintf("%x", blake2bHash.Sum(nil)),
+		"blake3":   fmt.Sprintf("%x", blake3Hash.Sum(nil)),
+	}
+
+	algos := make([]string, 0, len(expected))
+	for algo := range expected {
+		algos = append(algos, algo)
+	}
+
+	processor := NewFileProcessor(2, algos)
+	result := processor.calculate(context.Background(), tempFile, algos)
+
+	if result.Error != nil {
+		t.Fatalf("Error calculating digests: %v", result.Error)
+	}
+
+	for algo, want := range expected {
+		if got := result.Hashes[algo]; got != want {
+			t.Errorf("%s mismatch. Expected: %s, Got: %s", algo, want, got)
+		}
+	}
+}
+
+func TestCalculateDuplicateAlgorithmDoesNotDeadlock(t *testing.T) {
+	tempFile := "/tmp/test_dup_algo.txt"
+	content := "duplicate algorithm content"
+
+	if err := os.WriteFile(tempFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	defer os.Remove(tempFile)
+
+	hasher := sha256.New()
+	hasher.Write([]byte(content))
+	expectedHash := fmt.Sprintf("%x", hasher.Sum(nil))
+
+	processor := NewFileProcessor(2, nil)
+
+	done := make(chan FileResult, 1)
+	go func() {
+		done <- processor.calculate(context.Background(), tempFile, []string{"sha256", "sha256"})
+	}()
+
+	select {
+	case result := <-done:
+		if result.Error != nil {
+			t.Fatalf("Error calculating digest: %v", result.Error)
+		}
+		if result.Hashes["sha256"] != expectedHash {
+			t.Errorf("Hash mismatch. Expected: %s, Got: %s", expectedHash, result.Hashes["sha256"])
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("calculate deadlocked on a duplicate algorithm name")
+	}
+}
+
+func TestCalculateUnsupportedAlgorithm(t *testing.T) {
+	tempFile := "/tmp/test_unsupported_algo.txt"
+	if err := os.WriteFile(tempFile, []byte("data"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	defer os.Remove(tempFile)
+
+	processor := NewFileProcessor(2, nil)
+	result := processor.calculate(context.Background(), tempFile, []string{"crc32"})
+
+	if result.Error == nil {
+		t.Fatal("Expected an error for an unsupported algorithm")
+	}
+}
+
+func TestCalculateUnsupportedAlgorithmDoesNotLeakHasherGoroutines(t *testing.T) {
+	tempFile := "/tmp/test_unsupported_algo_leak.txt"
+	if err := os.WriteFile(tempFile, []byte("data"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	defer os.Remove(tempFile)
+
+	processor := NewFileProcessor(2, nil)
+
+	runtime.GC()
+	before := runtime.NumGoroutine()
+
+	// sha256 is valid and would normally start a hasher goroutine before
+	// the unsupported algorithm is reached; calculate must reject the
+	// whole batch up front instead of leaving that goroutine blocked.
+	for i := 0; i < 20; i++ {
+		result := processor.calculate(context.Background(), tempFile, []string{"sha256", "nonexistent"})
+		if result.Error == nil {
+			t.Fatal("Expected an error for an unsupported algorithm")
+		}
+	}
+
+	runtime.GC()
+	after := runtime.NumGoroutine()
+	if after > before+5 {
+		t.Errorf("Expected goroutine count to stay roughly stable, went from %d to %d", before, after)
+	}
+}
+
+func TestCalculateRespectsCancelledContext(t *testing.T) {
+	tempFile := "/tmp/test_cancelled_context.txt"
+	if err := os.WriteFile(tempFile, []byte("some content"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	defer os.Remove(tempFile)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	processor := NewFileProcessor(1, nil)
+	result := processor.calculate(ctx, tempFile, []string{"sha256"})
+
+	if result.Error == nil {
+		t.Fatal("Expected an error from an already-cancelled context")
+	}
+}
+
+func TestProcessFilesStopsOnCancel(t *testing.T) {
+	testFiles := []string{"/tmp/test_cancel1.txt", "/tmp/test_cancel2.txt"}
+	for _, f := range testFiles {
+		if err := os.WriteFile(f, []byte("data"), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+		defer os.Remove(f)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	processor := NewFileProcessor(2, nil)
+	results := processor.ProcessFilesBatch(ctx, testFiles)
+
+	// A context cancelled before any work starts should stop the job
+	// dispatcher promptly. Go's select doesn't guarantee which ready case
+	// fires first, so a worker may still pick up a path or two before the
+	// dispatcher notices cancellation; what must hold is that nothing gets
+	// hashed to completion.
+	for _, result := range results {
+		if result.Error == nil {
+			t.Errorf("Expected %s to fail against an already-cancelled context, got a result", result.Path)
+		}
+	}
+}
+
+func TestCalculateReportsProgress(t *testing.T) {
+	tempFile := "/tmp/test_progress.bin"
+	data := make([]byte, bufferSize*3)
+	if err := os.WriteFile(tempFile, data, 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	defer os.Remove(tempFile)
+
+	processor := NewFileProcessor(1, nil)
+	var calls int
+	var lastBytesRead int64
+	processor.Progress = func(path string, bytesRead, totalBytes int64) {
+		calls++
+		lastBytesRead = bytesRead
+		if totalBytes != int64(len(data)) {
+			t.Errorf("Expected totalBytes %d, got %d", len(data), totalBytes)
+		}
+	}
+
+	result := processor.calculate(context.Background(), tempFile, []string{"sha256"})
+	if result.Error != nil {
+		t.Fatalf("Unexpected error: %v", result.Error)
+	}
+	if calls == 0 {
+		t.Fatal("Expected at least one progress callback")
+	}
+	if lastBytesRead != int64(len(data)) {
+		t.Errorf("Expected final progress callback to report all %d bytes, got %d", len(data), lastBytesRead)
+	}
+}
+
 func TestProcessMultipleFiles(t *testing.T) {
-	processor := NewFileProcessor(2)
+	processor := NewFileProcessor(2, nil)
 	
 	// Create multiple test files
 	testFiles := []string{"/tmp/test1.txt", "/tmp/test2.txt", "/tmp/test3.txt"}
@@ -65,7 +293,7 @@ func TestProcessMultipleFiles(t *testing.T) {
 	}
 	
 	// Process files
-	results := processor.ProcessFiles(testFiles)
+	results := processor.ProcessFilesBatch(context.Background(), testFiles)
 	
 	if len(results) != len(testFiles) {
 		t.Errorf("Expected %d results, got %d", len(testFiles), len(results))
@@ -76,7 +304,7 @@ func TestProcessMultipleFiles(t *testing.T) {
 		if result.Error != nil {
 			t.Errorf("Error processing file %s: %v", result.Path, result.Error)
 		}
-		if result.Hash == "" {
+		if result.Hashes["sha256"] == "" {
 			t.Errorf("Empty hash for file %s", result.Path)
 		}
 	}
@@ -115,6 +343,157 @@ func TestReadFileList(t *testing.T) {
 	}
 }
 
+func TestParseChecksumLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		want    checksumEntry
+		wantErr bool
+	}{
+		{
+			name: "coreutils text mode",
+			line: strings.Repeat("a", 64) + "  file.txt",
+			want: checksumEntry{algo: "sha256", path: "file.txt", hash: strings.Repeat("a", 64)},
+		},
+		{
+			name: "coreutils binary mode",
+			line: strings.Repeat("b", 32) + " *file.bin",
+			want: checksumEntry{algo: "md5", path: "file.bin", hash: strings.Repeat("b", 32)},
+		},
+		{
+			name: "bsd tag format",
+			line: "SHA256 (file.txt) = " + strings.Repeat("c", 64),
+			want: checksumEntry{algo: "sha256", path: "file.txt", hash: strings.Repeat("c", 64)},
+		},
+		{
+			name:    "malformed line",
+			line:    "not a checksum line",
+			wantErr: true,
+		},
+		{
+			name:    "unrecognized digest length",
+			line:    "abcd  file.txt",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseChecksumLine(tt.line)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got entry %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("parseChecksumLine(%q) = %+v, want %+v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyChecksums(t *testing.T) {
+	okFile := "/tmp/verify_ok.txt"
+	badFile := "/tmp/verify_bad.txt"
+
+	if err := os.WriteFile(okFile, []byte("correct content"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	defer os.Remove(okFile)
+	if err := os.WriteFile(badFile, []byte("actual content"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	defer os.Remove(badFile)
+
+	okHash := sha256.New()
+	okHash.Write([]byte("correct content"))
+
+	manifest := fmt.Sprintf("%x  %s\n%s  %s\n", okHash.Sum(nil), okFile, strings.Repeat("0", 64), badFile)
+	manifestFile := "/tmp/verify_manifest.txt"
+	if err := os.WriteFile(manifestFile, []byte(manifest), 0644); err != nil {
+		t.Fatalf("Failed to create manifest: %v", err)
+	}
+	defer os.Remove(manifestFile)
+
+	mismatches, err := verifyChecksums(context.Background(), manifestFile, 2)
+	if err != nil {
+		t.Fatalf("verifyChecksums returned an error: %v", err)
+	}
+	if mismatches != 1 {
+		t.Errorf("Expected 1 mismatch, got %d", mismatches)
+	}
+}
+
+func TestVerifyChecksumsUnsupportedAlgoOnlyFailsItsOwnEntries(t *testing.T) {
+	okFile := "/tmp/verify_unsupported_ok.txt"
+	otherFile := "/tmp/verify_unsupported_other.txt"
+
+	if err := os.WriteFile(okFile, []byte("correct content"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	defer os.Remove(okFile)
+	if err := os.WriteFile(otherFile, []byte("rmd160 content"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	defer os.Remove(otherFile)
+
+	okHash := sha256.New()
+	okHash.Write([]byte("correct content"))
+
+	// A correct sha256 entry alongside an entry naming an algorithm this
+	// tool doesn't implement must not drag the sha256 entry down with it.
+	manifest := fmt.Sprintf("%x  %s\nRMD160 (%s) = %s\n", okHash.Sum(nil), okFile, otherFile, strings.Repeat("0", 40))
+	manifestFile := "/tmp/verify_unsupported_manifest.txt"
+	if err := os.WriteFile(manifestFile, []byte(manifest), 0644); err != nil {
+		t.Fatalf("Failed to create manifest: %v", err)
+	}
+	defer os.Remove(manifestFile)
+
+	mismatches, err := verifyChecksums(context.Background(), manifestFile, 2)
+	if err != nil {
+		t.Fatalf("verifyChecksums returned an error: %v", err)
+	}
+	if mismatches != 1 {
+		t.Errorf("Expected only the rmd160 entry to fail, got %d mismatches", mismatches)
+	}
+}
+
+func TestVerifyChecksumsSamePathMultipleAlgorithms(t *testing.T) {
+	file := "/tmp/verify_multi_algo.txt"
+	content := "content hashed with two algorithms"
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	defer os.Remove(file)
+
+	sha256Hash := sha256.New()
+	sha256Hash.Write([]byte(content))
+	md5Hash := md5.New()
+	md5Hash.Write([]byte(content))
+
+	// A combined manifest naming two correct algorithms for the same path
+	// must not let the second entry clobber the first in the lookup used
+	// to check the first.
+	manifest := fmt.Sprintf("%x  %s\nMD5 (%s) = %x\n", sha256Hash.Sum(nil), file, file, md5Hash.Sum(nil))
+	manifestFile := "/tmp/verify_multi_algo_manifest.txt"
+	if err := os.WriteFile(manifestFile, []byte(manifest), 0644); err != nil {
+		t.Fatalf("Failed to create manifest: %v", err)
+	}
+	defer os.Remove(manifestFile)
+
+	mismatches, err := verifyChecksums(context.Background(), manifestFile, 2)
+	if err != nil {
+		t.Fatalf("verifyChecksums returned an error: %v", err)
+	}
+	if mismatches != 0 {
+		t.Errorf("Expected both correct entries to pass, got %d mismatches", mismatches)
+	}
+}
+
 func TestFormatSize(t *testing.T) {
 	tests := []struct {
 		bytes    int64
@@ -140,16 +519,22 @@ func TestFormatSize(t *testing.T) {
 
 func TestNewFileProcessor(t *testing.T) {
 	// Test default worker count
-	processor := NewFileProcessor(0)
+	processor := NewFileProcessor(0, nil)
 	if processor.workerCount <= 0 {
 		t.Error("Worker count should be positive")
 	}
-	
+	if len(processor.algorithms) != 1 || processor.algorithms[0] != defaultAlgo {
+		t.Errorf("Expected default algorithm %q, got %v", defaultAlgo, processor.algorithms)
+	}
+
 	// Test specific worker count
-	processor = NewFileProcessor(8)
+	processor = NewFileProcessor(8, []string{"sha512"})
 	if processor.workerCount != 8 {
 		t.Errorf("Expected 8 workers, got %d", processor.workerCount)
 	}
+	if len(processor.algorithms) != 1 || processor.algorithms[0] != "sha512" {
+		t.Errorf("Expected algorithms [sha512], got %v", processor.algorithms)
+	}
 }
 
 func TestLargeFileProcessing(t *testing.T) {
@@ -177,23 +562,23 @@ func TestLargeFileProcessing(t *testing.T) {
 	file.Close()
 	defer os.Remove(tempFile)
 	
-	processor := NewFileProcessor(2)
-	result := processor.calculateSHA256(tempFile)
-	
+	processor := NewFileProcessor(2, nil)
+	result := processor.calculate(context.Background(), tempFile, []string{"sha256"})
+
 	if result.Error != nil {
 		t.Fatalf("Error calculating SHA256 for large file: %v", result.Error)
 	}
-	
+
 	if result.Size != int64(size) {
 		t.Errorf("Size mismatch. Expected: %d, Got: %d", size, result.Size)
 	}
-	
-	if len(result.Hash) != 64 { // SHA256 hash is 64 hex characters
-		t.Errorf("Invalid hash length. Expected: 64, Got: %d", len(result.Hash))
+
+	if len(result.Hashes["sha256"]) != 64 { // SHA256 hash is 64 hex characters
+		t.Errorf("Invalid hash length. Expected: 64, Got: %d", len(result.Hashes["sha256"]))
 	}
-	
+
 	// Verify the hash is hexadecimal
-	for _, c := range result.Hash {
+	for _, c := range result.Hashes["sha256"] {
 		if !((c >= '0' && c <= '9') || (c >= 'a' && c <= 'f')) {
 			t.Errorf("Hash contains non-hexadecimal character: %c", c)
 			break
@@ -201,6 +586,78 @@ func TestLargeFileProcessing(t *testing.T) {
 	}
 }
 
+func TestComputeTreeRoot(t *testing.T) {
+	tempFile := "/tmp/tree_test.bin"
+
+	// Two full chunks plus a short final chunk, so the "last chunk isn't
+	// padded" case is exercised alongside the common case.
+	size := treeChunkSize*2 + 100
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = byte(i % 256)
+	}
+	if err := os.WriteFile(tempFile, data, 0644); err != nil {
+		t.Fatalf("Failed to create tree test file: %v", err)
+	}
+	defer os.Remove(tempFile)
+
+	file, err := os.Open(tempFile)
+	if err != nil {
+		t.Fatalf("Failed to open tree test file: %v", err)
+	}
+	defer file.Close()
+
+	processor := NewFileProcessor(4, nil)
+	root, chunkHashes, err := processor.computeTreeRoot(context.Background(), file, int64(size))
+	if err != nil {
+		t.Fatalf("computeTreeRoot failed: %v", err)
+	}
+
+	if len(chunkHashes) != 3 {
+		t.Fatalf("Expected 3 chunk hashes, got %d", len(chunkHashes))
+	}
+
+	// Reproduce the construction independently: SHA-256 of each raw chunk,
+	// then SHA-256 of the concatenation of the raw (non-hex) chunk digests.
+	var concat []byte
+	for i := 0; i < 3; i++ {
+		start := i * treeChunkSize
+		end := start + treeChunkSize
+		if end > size {
+			end = size
+		}
+		sum := sha256.Sum256(data[start:end])
+		wantHash := fmt.Sprintf("%x", sum)
+		if chunkHashes[i] != wantHash {
+			t.Errorf("chunk %d hash mismatch. Expected: %s, Got: %s", i, wantHash, chunkHashes[i])
+		}
+		concat = append(concat, sum[:]...)
+	}
+	wantRoot := fmt.Sprintf("%x", sha256.Sum256(concat))
+	if root != wantRoot {
+		t.Errorf("Tree root mismatch. Expected: %s, Got: %s", wantRoot, root)
+	}
+}
+
+func TestCalculateTreeModeBelowThresholdSkipsTree(t *testing.T) {
+	tempFile := "/tmp/tree_small_test.bin"
+	if err := os.WriteFile(tempFile, []byte("hello world"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	defer os.Remove(tempFile)
+
+	processor := NewFileProcessor(2, nil)
+	processor.Tree = true
+	result := processor.calculate(context.Background(), tempFile, []string{"sha256"})
+
+	if result.Error != nil {
+		t.Fatalf("Unexpected error: %v", result.Error)
+	}
+	if result.TreeRoot != "" {
+		t.Errorf("Expected no tree root for a file below treeThreshold, got %q", result.TreeRoot)
+	}
+}
+
 // Benchmark test for performance
 func BenchmarkSHA256Calculation(b *testing.B) {
 	// Create a test file
@@ -210,20 +667,93 @@ func BenchmarkSHA256Calculation(b *testing.B) {
 	for i := range data {
 		data[i] = byte(i % 256)
 	}
-	
+
 	err := os.WriteFile(tempFile, data, 0644)
 	if err != nil {
 		b.Fatalf("Failed to create benchmark test file: %v", err)
 	}
 	defer os.Remove(tempFile)
-	
-	processor := NewFileProcessor(1)
-	
+
+	processor := NewFileProcessor(1, nil)
+
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		result := processor.calculateSHA256(tempFile)
+		result := processor.calculate(context.Background(), tempFile, []string{"sha256"})
 		if result.Error != nil {
 			b.Fatalf("Error in benchmark: %v", result.Error)
 		}
 	}
+}
+
+func makeBenchmarkFile(tb testing.TB, size int) string {
+	tb.Helper()
+	tempFile := fmt.Sprintf("/tmp/benchmark_multi_%d.bin", size)
+	data := make([]byte, 1024*1024)
+	for i := range data {
+		data[i] = byte(i % 256)
+	}
+
+	f, err := os.Create(tempFile)
+	if err != nil {
+		tb.Fatalf("Failed to create benchmark file: %v", err)
+	}
+	defer f.Close()
+
+	for written := 0; written < size; written += len(data) {
+		n := len(data)
+		if remaining := size - written; remaining < n {
+			n = remaining
+		}
+		if _, err := f.Write(data[:n]); err != nil {
+			tb.Fatalf("Failed to write benchmark file: %v", err)
+		}
+	}
+	return tempFile
+}
+
+var benchAlgos = []string{"sha256", "sha512", "md5"}
+
+// BenchmarkMultiAlgoFanOut measures the concurrent fan-out writer against
+// the multiple algorithms in benchAlgos on a 100MB file.
+func BenchmarkMultiAlgoFanOut(b *testing.B) {
+	tempFile := makeBenchmarkFile(b, 100*1024*1024)
+	defer os.Remove(tempFile)
+
+	processor := NewFileProcessor(len(benchAlgos), benchAlgos)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result := processor.calculate(context.Background(), tempFile, benchAlgos)
+		if result.Error != nil {
+			b.Fatalf("Error in benchmark: %v", result.Error)
+		}
+	}
+}
+
+// BenchmarkMultiAlgoMultiWriter is the io.MultiWriter baseline that
+// BenchmarkMultiAlgoFanOut is meant to outperform once hashers of very
+// different speeds (SHA-512, BLAKE2b, ...) are mixed in.
+func BenchmarkMultiAlgoMultiWriter(b *testing.B) {
+	tempFile := makeBenchmarkFile(b, 100*1024*1024)
+	defer os.Remove(tempFile)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		hashers := make([]hash.Hash, len(benchAlgos))
+		writers := make([]io.Writer, len(benchAlgos))
+		for j, algo := range benchAlgos {
+			hashers[j] = supportedAlgorithms[algo]()
+			writers[j] = hashers[j]
+		}
+
+		f, err := os.Open(tempFile)
+		if err != nil {
+			b.Fatalf("Failed to open benchmark file: %v", err)
+		}
+		if _, err := io.Copy(io.MultiWriter(writers...), f); err != nil {
+			f.Close()
+			b.Fatalf("Failed to hash benchmark file: %v", err)
+		}
+		f.Close()
+	}
 }
\ No newline at end of file